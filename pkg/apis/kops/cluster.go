@@ -0,0 +1,70 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Cluster represents a Kubernetes cluster and the infrastructure config needed to run it.
+type Cluster struct {
+	metav1.ObjectMeta
+
+	Spec ClusterSpec
+}
+
+// ClusterSpec defines the configuration for a cluster.
+type ClusterSpec struct {
+	// Subnets is the list of subnets the cluster's VPC is divided into.
+	Subnets []ClusterSubnetSpec
+
+	// KubernetesAPIAccess is the set of CIDRs permitted to reach the API.
+	KubernetesAPIAccess []string
+
+	// API holds the configuration for how the Kubernetes API is exposed.
+	API AccessSpec
+}
+
+// AccessSpec configures how a cluster's API is exposed.
+type AccessSpec struct {
+	// LoadBalancer, if set, fronts the API with a LoadBalancer.
+	LoadBalancer *LoadBalancerAccessSpec
+}
+
+// InstanceGroupRole indicates which role an InstanceGroup plays in a cluster.
+type InstanceGroupRole string
+
+const (
+	InstanceGroupRoleMaster  InstanceGroupRole = "Master"
+	InstanceGroupRoleNode    InstanceGroupRole = "Node"
+	InstanceGroupRoleBastion InstanceGroupRole = "Bastion"
+)
+
+// InstanceGroup represents a group of instances sharing the same configuration, e.g. the masters.
+type InstanceGroup struct {
+	metav1.ObjectMeta
+
+	Spec InstanceGroupSpec
+}
+
+// InstanceGroupSpec is the configuration for an InstanceGroup.
+type InstanceGroupSpec struct {
+	Role InstanceGroupRole
+
+	// Subnets is the list of subnet names this instance group is spread across.
+	Subnets []string
+}
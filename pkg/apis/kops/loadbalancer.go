@@ -0,0 +1,120 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+// LoadBalancerType indicates whether the API LoadBalancer is reachable from the internet.
+type LoadBalancerType string
+
+const (
+	LoadBalancerTypeInternal LoadBalancerType = "Internal"
+	LoadBalancerTypePublic   LoadBalancerType = "Public"
+)
+
+// LoadBalancerClass selects the underlying AWS load balancer product fronting the API.
+type LoadBalancerClass string
+
+const (
+	// LoadBalancerClassClassic is a Classic ELB, the longstanding default.
+	LoadBalancerClassClassic LoadBalancerClass = "Classic"
+	// LoadBalancerClassNetwork is a Network Load Balancer.
+	LoadBalancerClassNetwork LoadBalancerClass = "Network"
+)
+
+// LoadBalancerAccessSpec configures the load balancer that fronts the Kubernetes API.
+type LoadBalancerAccessSpec struct {
+	// Type is whether the LoadBalancer is Internal or Public.
+	Type LoadBalancerType
+
+	// Class selects Classic ELB (the default) or Network Load Balancer.
+	Class LoadBalancerClass
+
+	// IdleTimeoutSeconds overrides LoadBalancerDefaultIdleTimeout.
+	IdleTimeoutSeconds *int64
+
+	// Subnets, if set, pins the LoadBalancer to exactly these subnets instead of letting kops
+	// choose one per zone.
+	Subnets []LoadBalancerSubnetSpec
+
+	// SSLCertificate is the ARN of an ACM certificate to terminate TLS at the ELB with. If set,
+	// the 443 listener becomes HTTPS->HTTPS instead of a plain TCP passthrough.
+	SSLCertificate string
+
+	// SSLPolicy is the ELB security policy (e.g. ELBSecurityPolicy-TLS-1-2-2017-01) to negotiate
+	// with when SSLCertificate is set.
+	SSLPolicy string
+
+	// AdditionalSecurityGroups attaches customer-managed security groups to the ELB, alongside
+	// the one kops manages itself.
+	AdditionalSecurityGroups []string
+
+	// AccessLog configures the ELB's access logs, for troubleshooting apiserver connectivity.
+	AccessLog *AccessLogSpec
+
+	// ConnectionDraining configures connection draining on deregistration.
+	ConnectionDraining *ConnectionDrainingSpec
+
+	// CrossZoneLoadBalancing configures whether the ELB distributes traffic evenly across all
+	// registered AZs. Disabling it can matter for multi-region cost control.
+	CrossZoneLoadBalancing *CrossZoneLoadBalancingSpec
+
+	// AdditionalListeners declares extra front-end ports the ELB should forward, alongside the
+	// API's own 443 listener - e.g. for konnectivity, a separate OIDC discovery port, or a
+	// webhook aggregator sharing the API's DNS name.
+	AdditionalListeners []LoadBalancerAdditionalListenerSpec
+}
+
+// LoadBalancerAdditionalListenerSpec configures one extra ELB listener beyond the API's own.
+type LoadBalancerAdditionalListenerSpec struct {
+	// Port is the external port the ELB listens on.
+	Port int32
+	// InstancePort is the port on the master instances traffic is forwarded to.
+	InstancePort int32
+	// Protocol is the listener protocol, e.g. TCP or SSL. Defaults to the listener task's own
+	// default protocol when empty.
+	Protocol string
+	// SSLCertificate is the ARN of an ACM certificate to terminate TLS with on this listener.
+	SSLCertificate string
+}
+
+// AccessLogSpec configures an ELB's access logging.
+type AccessLogSpec struct {
+	Enabled        bool
+	S3BucketName   string
+	S3BucketPrefix string
+	EmitInterval   int64
+}
+
+// ConnectionDrainingSpec configures an ELB's connection draining on deregistration.
+type ConnectionDrainingSpec struct {
+	Enabled bool
+	Timeout int64
+}
+
+// CrossZoneLoadBalancingSpec configures whether an ELB distributes traffic evenly across all
+// registered availability zones.
+type CrossZoneLoadBalancingSpec struct {
+	Enabled bool
+}
+
+// LoadBalancerSubnetSpec names a subnet (by ClusterSubnetSpec.Name) that an explicitly-configured
+// LoadBalancer should attach to, optionally overriding its static IP or EIP allocation.
+type LoadBalancerSubnetSpec struct {
+	Name string
+
+	PrivateIPv4Address *string
+	AllocationID       *string
+}
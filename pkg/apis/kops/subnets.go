@@ -0,0 +1,55 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+// SubnetType defines the role a subnet plays in the cluster's networking topology.
+type SubnetType string
+
+const (
+	SubnetTypePublic  SubnetType = "Public"
+	SubnetTypePrivate SubnetType = "Private"
+	SubnetTypeUtility SubnetType = "Utility"
+)
+
+const (
+	// SubnetRoleELB marks a subnet as eligible for a public-facing LoadBalancer, mirroring the
+	// kubernetes.io/role/elb tag convention the upstream AWS cloud provider uses.
+	SubnetRoleELB = "kubernetes.io/role/elb"
+	// SubnetRoleInternalELB marks a subnet as eligible for an internal LoadBalancer, mirroring
+	// the kubernetes.io/role/internal-elb tag convention.
+	SubnetRoleInternalELB = "kubernetes.io/role/internal-elb"
+)
+
+// ClusterSubnetSpec describes a single subnet within the cluster's VPC.
+type ClusterSubnetSpec struct {
+	Name string
+	Zone string
+	Type SubnetType
+
+	// Roles records any kubernetes.io/role/* tags discovered for this subnet (e.g. SubnetRoleELB,
+	// SubnetRoleInternalELB). When set, a subnet's Roles are authoritative for LoadBalancer subnet
+	// selection, overriding the Type-based public/private/utility fallback.
+	Roles []string
+
+	// PrivateIPv4Address pins a static private IP for this subnet, for use by a Network Load
+	// Balancer that needs a predictable address per AZ.
+	PrivateIPv4Address *string
+
+	// AllocationID is the EIP allocation ID to associate with this subnet, for a public Network
+	// Load Balancer that needs a static IP.
+	AllocationID *string
+}
@@ -19,6 +19,7 @@ package model
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/golang/glog"
@@ -57,36 +58,26 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 		return fmt.Errorf("unhandled LoadBalancer type %q", lbSpec.Type)
 	}
 
-	// Compute the subnets - only one per zone, and then break ties based on chooseBestSubnetForELB
-	var elbSubnets []*awstasks.Subnet
-	{
-		subnetsByZone := make(map[string][]*kops.ClusterSubnetSpec)
-		for i := range b.Cluster.Spec.Subnets {
-			subnet := &b.Cluster.Spec.Subnets[i]
-
-			switch subnet.Type {
-			case kops.SubnetTypePublic, kops.SubnetTypeUtility:
-				if lbSpec.Type != kops.LoadBalancerTypePublic {
-					continue
-				}
-
-			case kops.SubnetTypePrivate:
-				if lbSpec.Type != kops.LoadBalancerTypeInternal {
-					continue
-				}
-
-			default:
-				return fmt.Errorf("subnet %q had unknown type %q", subnet.Name, subnet.Type)
-			}
-
-			subnetsByZone[subnet.Zone] = append(subnetsByZone[subnet.Zone], subnet)
-		}
+	switch lbSpec.Class {
+	case "", kops.LoadBalancerClassClassic:
+		return b.buildClassicLoadBalancer(c, lbSpec)
+	case kops.LoadBalancerClassNetwork:
+		return b.buildNetworkLoadBalancer(c, lbSpec)
+	default:
+		return fmt.Errorf("unhandled LoadBalancer class %q", lbSpec.Class)
+	}
+}
 
-		for zone, subnets := range subnetsByZone {
-			subnet := b.chooseBestSubnetForELB(zone, subnets)
+// buildClassicLoadBalancer builds a classic ELB fronting the API, the longstanding default.
+func (b *APILoadBalancerBuilder) buildClassicLoadBalancer(c *fi.ModelBuilderContext, lbSpec *kops.LoadBalancerAccessSpec) error {
+	subnets, err := b.findLoadBalancerSubnets(lbSpec)
+	if err != nil {
+		return err
+	}
 
-			elbSubnets = append(elbSubnets, b.LinkToSubnet(subnet))
-		}
+	var elbSubnets []*awstasks.Subnet
+	for _, subnet := range subnets {
+		elbSubnets = append(elbSubnets, b.LinkToSubnet(subnet))
 	}
 
 	var elb *awstasks.LoadBalancer
@@ -98,20 +89,45 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 			idleTimeout = time.Second * time.Duration(*lbSpec.IdleTimeoutSeconds)
 		}
 
+		listener := &awstasks.LoadBalancerListener{InstancePort: 443}
+		healthCheckTarget := "TCP:443"
+		if lbSpec.SSLCertificate != "" {
+			listener.SSLCertificateID = s(lbSpec.SSLCertificate)
+			listener.SSLPolicy = s(lbSpec.SSLPolicy)
+			healthCheckTarget = "SSL:443"
+		}
+
+		securityGroups := []*awstasks.SecurityGroup{
+			b.LinkToELBSecurityGroup("api"),
+		}
+		for _, id := range lbSpec.AdditionalSecurityGroups {
+			securityGroups = append(securityGroups, b.LinkToSecurityGroupByID(id))
+		}
+
+		listeners := map[string]*awstasks.LoadBalancerListener{
+			"443": listener,
+		}
+		for _, additional := range lbSpec.AdditionalListeners {
+			additionalListener := &awstasks.LoadBalancerListener{InstancePort: int64(additional.InstancePort)}
+			if additional.Protocol != "" {
+				additionalListener.Protocol = s(additional.Protocol)
+			}
+			if additional.SSLCertificate != "" {
+				additionalListener.SSLCertificateID = s(additional.SSLCertificate)
+			}
+			listeners[strconv.Itoa(int(additional.Port))] = additionalListener
+		}
+
 		elb = &awstasks.LoadBalancer{
 			Name:             s("api." + b.ClusterName()),
 			LoadBalancerName: s(loadBalancerName),
-			SecurityGroups: []*awstasks.SecurityGroup{
-				b.LinkToELBSecurityGroup("api"),
-			},
-			Subnets: elbSubnets,
-			Listeners: map[string]*awstasks.LoadBalancerListener{
-				"443": {InstancePort: 443},
-			},
+			SecurityGroups:   securityGroups,
+			Subnets:          elbSubnets,
+			Listeners:        listeners,
 
 			// Configure fast-recovery health-checks
 			HealthCheck: &awstasks.LoadBalancerHealthCheck{
-				Target:             s("TCP:443"),
+				Target:             s(healthCheckTarget),
 				Timeout:            i64(5),
 				Interval:           i64(10),
 				HealthyThreshold:   i64(2),
@@ -123,6 +139,28 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 			},
 		}
 
+		if lbSpec.AccessLog != nil {
+			elb.AccessLog = &awstasks.LoadBalancerAccessLog{
+				Enabled:        fi.Bool(lbSpec.AccessLog.Enabled),
+				S3BucketName:   s(lbSpec.AccessLog.S3BucketName),
+				S3BucketPrefix: s(lbSpec.AccessLog.S3BucketPrefix),
+				EmitInterval:   i64(lbSpec.AccessLog.EmitInterval),
+			}
+		}
+
+		if lbSpec.ConnectionDraining != nil {
+			elb.ConnectionDraining = &awstasks.LoadBalancerConnectionDraining{
+				Enabled: fi.Bool(lbSpec.ConnectionDraining.Enabled),
+				Timeout: i64(lbSpec.ConnectionDraining.Timeout),
+			}
+		}
+
+		if lbSpec.CrossZoneLoadBalancing != nil {
+			elb.CrossZoneLoadBalancing = &awstasks.LoadBalancerCrossZoneLoadBalancing{
+				Enabled: fi.Bool(lbSpec.CrossZoneLoadBalancing.Enabled),
+			}
+		}
+
 		switch lbSpec.Type {
 		case kops.LoadBalancerTypeInternal:
 			elb.Scheme = s("internal")
@@ -172,6 +210,21 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 	}
 
+	// Allow traffic into the ELB from KubernetesAPIAccess CIDRs, for each additional listener port
+	for _, additional := range lbSpec.AdditionalListeners {
+		for _, cidr := range b.Cluster.Spec.KubernetesAPIAccess {
+			t := &awstasks.SecurityGroupRule{
+				Name:          s(fmt.Sprintf("api-elb-%d-%s", additional.Port, cidr)),
+				SecurityGroup: b.LinkToELBSecurityGroup("api"),
+				CIDR:          s(cidr),
+				FromPort:      i64(int64(additional.Port)),
+				ToPort:        i64(int64(additional.Port)),
+				Protocol:      s("tcp"),
+			}
+			c.AddTask(t)
+		}
+	}
+
 	// Allow HTTPS to the master instances from the ELB
 	{
 		t := &awstasks.SecurityGroupRule{
@@ -185,6 +238,19 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 		c.AddTask(t)
 	}
 
+	// Allow traffic to the master instances from the ELB, for each additional listener port
+	for _, additional := range lbSpec.AdditionalListeners {
+		t := &awstasks.SecurityGroupRule{
+			Name:          s(fmt.Sprintf("elb-to-master-%d", additional.Port)),
+			SecurityGroup: b.LinkToSecurityGroup(kops.InstanceGroupRoleMaster),
+			SourceGroup:   b.LinkToELBSecurityGroup("api"),
+			FromPort:      i64(int64(additional.InstancePort)),
+			ToPort:        i64(int64(additional.InstancePort)),
+			Protocol:      s("tcp"),
+		}
+		c.AddTask(t)
+	}
+
 	for _, ig := range b.MasterInstanceGroups() {
 		t := &awstasks.LoadBalancerAttachment{
 			Name: s("api-" + ig.ObjectMeta.Name),
@@ -200,6 +266,228 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 
 }
 
+// buildNetworkLoadBalancer builds a Network Load Balancer fronting the API. NLBs have no
+// security group of their own, so the master security group is widened to allow
+// KubernetesAPIAccess CIDRs directly, and traffic is load balanced via a TargetGroup
+// rather than the classic LoadBalancerAttachment mechanism.
+func (b *APILoadBalancerBuilder) buildNetworkLoadBalancer(c *fi.ModelBuilderContext, lbSpec *kops.LoadBalancerAccessSpec) error {
+	// Compute the subnet mappings - one per zone, honoring any static IP/EIP overrides
+	subnets, err := b.findLoadBalancerSubnets(lbSpec)
+	if err != nil {
+		return err
+	}
+
+	var subnetMappings []*awstasks.SubnetMapping
+	for _, subnet := range subnets {
+		mapping := &awstasks.SubnetMapping{
+			Subnet: b.LinkToSubnet(subnet),
+		}
+		if subnet.PrivateIPv4Address != nil {
+			mapping.PrivateIPv4Address = subnet.PrivateIPv4Address
+		}
+		if subnet.AllocationID != nil {
+			mapping.AllocationID = subnet.AllocationID
+		}
+		subnetMappings = append(subnetMappings, mapping)
+	}
+
+	targetGroup := &awstasks.TargetGroup{
+		Name:     s("api-" + b.ClusterName()),
+		VPC:      b.LinkToVPC(),
+		Port:     i64(443),
+		Protocol: s("TCP"),
+		HealthCheck: &awstasks.TargetGroupHealthCheck{
+			Protocol:           s("HTTPS"),
+			Path:               s("/healthz"),
+			Port:               i64(443),
+			Interval:           i64(10),
+			Timeout:            i64(5),
+			HealthyThreshold:   i64(2),
+			UnhealthyThreshold: i64(2),
+		},
+	}
+
+	c.AddTask(targetGroup)
+
+	nlb := &awstasks.NetworkLoadBalancer{
+		Name:             s("api." + b.ClusterName()),
+		LoadBalancerName: s(b.GetELBName32("api")),
+		SubnetMappings:   subnetMappings,
+	}
+
+	switch lbSpec.Type {
+	case kops.LoadBalancerTypeInternal:
+		nlb.Scheme = s("internal")
+	case kops.LoadBalancerTypePublic:
+		nlb.Scheme = nil
+	}
+
+	c.AddTask(nlb)
+
+	c.AddTask(&awstasks.Listener{
+		Name:         s("api-443-" + b.ClusterName()),
+		LoadBalancer: b.LinkToNetworkLoadBalancer("api"),
+		TargetGroup:  b.LinkToTargetGroup("api"),
+		Port:         i64(443),
+		Protocol:     s("TCP"),
+	})
+
+	// NLBs have no security group of their own: widen the master security group to allow
+	// the KubernetesAPIAccess CIDRs directly, instead of routing through an ELB SG.
+	for _, cidr := range b.Cluster.Spec.KubernetesAPIAccess {
+		t := &awstasks.SecurityGroupRule{
+			Name:          s("https-api-nlb-" + cidr),
+			SecurityGroup: b.LinkToSecurityGroup(kops.InstanceGroupRoleMaster),
+			CIDR:          s(cidr),
+			FromPort:      i64(443),
+			ToPort:        i64(443),
+			Protocol:      s("tcp"),
+		}
+		c.AddTask(t)
+	}
+
+	for _, ig := range b.MasterInstanceGroups() {
+		t := &awstasks.TargetGroupAttachment{
+			Name: s("api-" + ig.ObjectMeta.Name),
+
+			TargetGroup:      b.LinkToTargetGroup("api"),
+			AutoscalingGroup: b.LinkToAutoscalingGroup(ig),
+		}
+
+		c.AddTask(t)
+	}
+
+	return nil
+}
+
+// findLoadBalancerSubnets returns the subnets the API LoadBalancer should attach to, one
+// per zone. If the user has listed explicit subnets in lbSpec.Subnets, those are used
+// verbatim; otherwise every eligible subnet in the cluster is gathered and, where a zone
+// has more than one candidate, chooseBestSubnetForELB breaks the tie.
+//
+// If a given subnet carries any kubernetes.io/role/elb or kubernetes.io/role/internal-elb role
+// (recorded in ClusterSubnetSpec.Roles), that subnet's role tags become authoritative for it:
+// it is included only if they match the requested LoadBalancerType, and the usual
+// public/utility/private subnet Type check is bypassed for that subnet. Subnets with no Roles
+// at all still fall back to the Type check, so an untagged cluster behaves exactly as before.
+func (b *APILoadBalancerBuilder) findLoadBalancerSubnets(lbSpec *kops.LoadBalancerAccessSpec) ([]*kops.ClusterSubnetSpec, error) {
+	if len(lbSpec.Subnets) != 0 {
+		return b.findExplicitLoadBalancerSubnets(lbSpec)
+	}
+
+	subnetsByZone := make(map[string][]*kops.ClusterSubnetSpec)
+	for i := range b.Cluster.Spec.Subnets {
+		subnet := &b.Cluster.Spec.Subnets[i]
+
+		if hasRoles, matches := subnetRoleMatches(subnet, lbSpec.Type); hasRoles {
+			if matches {
+				subnetsByZone[subnet.Zone] = append(subnetsByZone[subnet.Zone], subnet)
+			}
+			continue
+		}
+
+		switch subnet.Type {
+		case kops.SubnetTypePublic, kops.SubnetTypeUtility:
+			if lbSpec.Type != kops.LoadBalancerTypePublic {
+				continue
+			}
+
+		case kops.SubnetTypePrivate:
+			if lbSpec.Type != kops.LoadBalancerTypeInternal {
+				continue
+			}
+
+		default:
+			return nil, fmt.Errorf("subnet %q had unknown type %q", subnet.Name, subnet.Type)
+		}
+
+		subnetsByZone[subnet.Zone] = append(subnetsByZone[subnet.Zone], subnet)
+	}
+
+	var subnets []*kops.ClusterSubnetSpec
+	for zone, zoneSubnets := range subnetsByZone {
+		subnets = append(subnets, b.chooseBestSubnetForELB(zone, zoneSubnets))
+	}
+	return subnets, nil
+}
+
+// subnetRoleMatches reports whether subnet carries any kubernetes.io/role/* tags (recorded in
+// ClusterSubnetSpec.Roles) and, if so, whether they include the role required for lbType. hasRoles
+// is false when the subnet has no Roles at all, in which case the caller should fall back to the
+// subnet's Type instead; when hasRoles is true, the Roles are authoritative and the caller should
+// trust matches rather than consulting Type.
+func subnetRoleMatches(subnet *kops.ClusterSubnetSpec, lbType kops.LoadBalancerType) (hasRoles bool, matches bool) {
+	if len(subnet.Roles) == 0 {
+		return false, false
+	}
+
+	role := kops.SubnetRoleELB
+	if lbType == kops.LoadBalancerTypeInternal {
+		role = kops.SubnetRoleInternalELB
+	}
+
+	return true, sets.NewString(subnet.Roles...).Has(role)
+}
+
+// findExplicitLoadBalancerSubnets resolves lbSpec.Subnets against the cluster's subnets,
+// validating that each one matches the LoadBalancer's type and that no two share a zone. As in
+// findLoadBalancerSubnets, a subnet's role tags (via subnetRoleMatches) are authoritative over
+// its Type when present.
+func (b *APILoadBalancerBuilder) findExplicitLoadBalancerSubnets(lbSpec *kops.LoadBalancerAccessSpec) ([]*kops.ClusterSubnetSpec, error) {
+	byName := make(map[string]*kops.ClusterSubnetSpec)
+	for i := range b.Cluster.Spec.Subnets {
+		subnet := &b.Cluster.Spec.Subnets[i]
+		byName[subnet.Name] = subnet
+	}
+
+	zonesSeen := sets.NewString()
+	var subnets []*kops.ClusterSubnetSpec
+	for _, lbSubnet := range lbSpec.Subnets {
+		subnet, ok := byName[lbSubnet.Name]
+		if !ok {
+			return nil, fmt.Errorf("api.loadBalancer.subnets references unknown subnet %q", lbSubnet.Name)
+		}
+
+		if hasRoles, matches := subnetRoleMatches(subnet, lbSpec.Type); hasRoles {
+			if !matches {
+				return nil, fmt.Errorf("subnet %q is tagged for a different LoadBalancer type than requested", subnet.Name)
+			}
+		} else {
+			switch subnet.Type {
+			case kops.SubnetTypePublic, kops.SubnetTypeUtility:
+				if lbSpec.Type != kops.LoadBalancerTypePublic {
+					return nil, fmt.Errorf("subnet %q cannot be used by an internal LoadBalancer", subnet.Name)
+				}
+			case kops.SubnetTypePrivate:
+				if lbSpec.Type != kops.LoadBalancerTypeInternal {
+					return nil, fmt.Errorf("subnet %q cannot be used by a public LoadBalancer", subnet.Name)
+				}
+			default:
+				return nil, fmt.Errorf("subnet %q had unknown type %q", subnet.Name, subnet.Type)
+			}
+		}
+
+		if zonesSeen.Has(subnet.Zone) {
+			return nil, fmt.Errorf("api.loadBalancer.subnets cannot list two subnets in the same zone %q", subnet.Zone)
+		}
+		zonesSeen.Insert(subnet.Zone)
+
+		if lbSubnet.PrivateIPv4Address != nil || lbSubnet.AllocationID != nil {
+			merged := *subnet
+			if lbSubnet.PrivateIPv4Address != nil {
+				merged.PrivateIPv4Address = lbSubnet.PrivateIPv4Address
+			}
+			if lbSubnet.AllocationID != nil {
+				merged.AllocationID = lbSubnet.AllocationID
+			}
+			subnet = &merged
+		}
+
+		subnets = append(subnets, subnet)
+	}
+	return subnets, nil
+}
+
 type scoredSubnet struct {
 	score  int
 	subnet *kops.ClusterSubnetSpec
@@ -220,7 +508,8 @@ func (a ByScoreDescending) Less(i, j int) bool {
 
 // Choose between subnets in a zone.
 // We have already applied the rules to match internal subnets to internal ELBs and vice-versa for public-facing ELBs.
-// For internal ELBs: we prefer the master subnets
+// A subnet carrying the matching kubernetes.io/role/elb or kubernetes.io/role/internal-elb tag always wins.
+// Otherwise, for internal ELBs: we prefer the master subnets
 // For public facing ELBs: we prefer the utility subnets
 func (b *APILoadBalancerBuilder) chooseBestSubnetForELB(zone string, subnets []*kops.ClusterSubnetSpec) *kops.ClusterSubnetSpec {
 	if len(subnets) == 0 {
@@ -241,6 +530,13 @@ func (b *APILoadBalancerBuilder) chooseBestSubnetForELB(zone string, subnets []*
 	for _, subnet := range subnets {
 		score := 0
 
+		// A subnet explicitly tagged kubernetes.io/role/elb or kubernetes.io/role/internal-elb
+		// wins over any other heuristic.
+		roles := sets.NewString(subnet.Roles...)
+		if roles.Has(kops.SubnetRoleELB) || roles.Has(kops.SubnetRoleInternalELB) {
+			score += 10
+		}
+
 		if migSubnets.Has(subnet.Name) {
 			score += 1
 		}
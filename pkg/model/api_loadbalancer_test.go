@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func subnetNames(subnets []*kops.ClusterSubnetSpec) []string {
+	var names []string
+	for _, subnet := range subnets {
+		names = append(names, subnet.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestFindLoadBalancerSubnets_MixedTaggedAndUntagged is a regression test for a bug where a role
+// tag on any subnet in the cluster short-circuited the Type-based fallback for every subnet, not
+// just the tagged ones - silently dropping untagged zones from the result instead of falling back
+// to their Type.
+func TestFindLoadBalancerSubnets_MixedTaggedAndUntagged(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.example.com"},
+		Spec: kops.ClusterSpec{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "utility-a", Zone: "us-east-1a", Type: kops.SubnetTypeUtility},
+				{Name: "private-a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+				{Name: "public-b", Zone: "us-east-1b", Type: kops.SubnetTypePublic, Roles: []string{kops.SubnetRoleELB}},
+				{Name: "private-b", Zone: "us-east-1b", Type: kops.SubnetTypePrivate},
+			},
+		},
+	}
+	b := &APILoadBalancerBuilder{KopsModelContext: &KopsModelContext{Cluster: cluster}}
+
+	grid := []struct {
+		name     string
+		lbType   kops.LoadBalancerType
+		expected []string
+	}{
+		{
+			// Only "public-b" carries a Roles tag, and it is for the public ELB role, not the
+			// internal one being asked about here. An internal LB must still fall back to Type
+			// for the untagged subnets: private-a and private-b, not an empty result.
+			name:     "internal falls back to Type for untagged zones",
+			lbType:   kops.LoadBalancerTypeInternal,
+			expected: []string{"private-a", "private-b"},
+		},
+		{
+			// "public-b" is tagged for kubernetes.io/role/elb, so it wins zone b outright; zone a
+			// has no tagged subnets, so it falls back to Type (Utility matches Public).
+			name:     "public uses the tagged subnet and falls back to Type elsewhere",
+			lbType:   kops.LoadBalancerTypePublic,
+			expected: []string{"public-b", "utility-a"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			lbSpec := &kops.LoadBalancerAccessSpec{Type: g.lbType}
+
+			subnets, err := b.findLoadBalancerSubnets(lbSpec)
+			if err != nil {
+				t.Fatalf("findLoadBalancerSubnets: %v", err)
+			}
+
+			actual := subnetNames(subnets)
+			if len(actual) != len(g.expected) {
+				t.Fatalf("expected subnets %v, got %v", g.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != g.expected[i] {
+					t.Fatalf("expected subnets %v, got %v", g.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+// TestChooseBestSubnetForELB_RoleTagWins verifies that a role-tagged subnet is preferred over an
+// untagged one in the same zone, even when the untagged one would otherwise win on the
+// master-subnet/utility heuristics.
+func TestChooseBestSubnetForELB_RoleTagWins(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.example.com"},
+	}
+	masterIG := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "master-us-east-1a"},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleMaster,
+			Subnets: []string{"private-a"},
+		},
+	}
+	b := &APILoadBalancerBuilder{KopsModelContext: &KopsModelContext{
+		Cluster:        cluster,
+		InstanceGroups: []*kops.InstanceGroup{masterIG},
+	}}
+
+	// private-a is the master subnet (score +1 from migSubnets); utility-a is a Utility subnet
+	// (score +1) and also carries the internal-elb role tag (score +10). The role tag must win.
+	subnets := []*kops.ClusterSubnetSpec{
+		{Name: "private-a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+		{Name: "utility-a", Zone: "us-east-1a", Type: kops.SubnetTypeUtility, Roles: []string{kops.SubnetRoleInternalELB}},
+	}
+
+	chosen := b.chooseBestSubnetForELB("us-east-1a", subnets)
+	if chosen == nil || chosen.Name != "utility-a" {
+		t.Fatalf("expected utility-a to win on its role tag, got %v", chosen)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+// awsELBNameMaxLength is the maximum length AWS allows for a Classic ELB or NLB name.
+const awsELBNameMaxLength = 32
+
+// KopsModelContext holds the cluster configuration shared by every ModelBuilder, along with the
+// naming and linking helpers they use to refer to each other's Tasks.
+type KopsModelContext struct {
+	Cluster        *kops.Cluster
+	InstanceGroups []*kops.InstanceGroup
+}
+
+// s returns a pointer to the string value passed in, for building Task field literals concisely.
+func s(v string) *string {
+	return fi.String(v)
+}
+
+// i64 returns a pointer to the int64 value passed in, for building Task field literals concisely.
+func i64(v int64) *int64 {
+	return fi.Int64(v)
+}
+
+// ClusterName returns the name of the cluster being modeled.
+func (c *KopsModelContext) ClusterName() string {
+	return c.Cluster.ObjectMeta.Name
+}
+
+// UseLoadBalancerForAPI is true if the API is fronted by a LoadBalancer.
+func (c *KopsModelContext) UseLoadBalancerForAPI() bool {
+	return c.Cluster.Spec.API.LoadBalancer != nil
+}
+
+// MasterInstanceGroups returns the InstanceGroups playing the Master role.
+func (c *KopsModelContext) MasterInstanceGroups() []*kops.InstanceGroup {
+	var masters []*kops.InstanceGroup
+	for _, ig := range c.InstanceGroups {
+		if ig.Spec.Role == kops.InstanceGroupRoleMaster {
+			masters = append(masters, ig)
+		}
+	}
+	return masters
+}
+
+// GetELBName32 builds an ELB/NLB name for the given prefix, qualified by the cluster name, and
+// guarantees the result fits within AWS's 32-character Classic ELB/NLB name limit: names that
+// would otherwise exceed it are truncated and given a short content hash suffix, so two clusters
+// sharing a long common prefix don't collide once truncated.
+func (c *KopsModelContext) GetELBName32(prefix string) string {
+	name := prefix + "-" + strings.Replace(c.ClusterName(), ".", "-", -1)
+	return awsTruncateName(name, awsELBNameMaxLength)
+}
+
+// awsTruncateName shortens name to at most maxLength characters. If truncation is needed, the
+// tail of the name is replaced with an 8-character hash of the full original name, so that two
+// distinct inputs sharing a long common prefix still produce distinct results.
+func awsTruncateName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+
+	hash := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(hash[:])[:8]
+
+	return name[:maxLength-len(suffix)] + suffix
+}
+
+// ELBSecurityGroupName builds the name of the SecurityGroup fronting the given ELB prefix.
+func (c *KopsModelContext) ELBSecurityGroupName(prefix string) string {
+	return prefix + "-elb." + c.ClusterName()
+}
+
+// SecurityGroupName builds the name of the SecurityGroup for instances playing the given role.
+func (c *KopsModelContext) SecurityGroupName(role kops.InstanceGroupRole) string {
+	switch role {
+	case kops.InstanceGroupRoleMaster:
+		return "masters." + c.ClusterName()
+	case kops.InstanceGroupRoleNode:
+		return "nodes." + c.ClusterName()
+	case kops.InstanceGroupRoleBastion:
+		return "bastions." + c.ClusterName()
+	default:
+		panic(fmt.Sprintf("unknown InstanceGroupRole %q", role))
+	}
+}
+
+// LinkToVPC returns a reference to the cluster's VPC Task.
+func (c *KopsModelContext) LinkToVPC() *awstasks.VPC {
+	return &awstasks.VPC{Name: s(c.ClusterName())}
+}
+
+// LinkToSubnet returns a reference to the Task for the named cluster subnet.
+func (c *KopsModelContext) LinkToSubnet(subnet *kops.ClusterSubnetSpec) *awstasks.Subnet {
+	return &awstasks.Subnet{Name: s(subnet.Name + "." + c.ClusterName())}
+}
+
+// LinkToELB returns a reference to the classic ELB Task with the given prefix.
+func (c *KopsModelContext) LinkToELB(prefix string) *awstasks.LoadBalancer {
+	return &awstasks.LoadBalancer{Name: s(prefix + "." + c.ClusterName())}
+}
+
+// LinkToNetworkLoadBalancer returns a reference to the NLB Task with the given prefix.
+func (c *KopsModelContext) LinkToNetworkLoadBalancer(prefix string) *awstasks.NetworkLoadBalancer {
+	return &awstasks.NetworkLoadBalancer{Name: s(prefix + "." + c.ClusterName())}
+}
+
+// LinkToTargetGroup returns a reference to the TargetGroup Task with the given prefix.
+func (c *KopsModelContext) LinkToTargetGroup(prefix string) *awstasks.TargetGroup {
+	return &awstasks.TargetGroup{Name: s(prefix + "-" + c.ClusterName())}
+}
+
+// LinkToELBSecurityGroup returns a reference to the SecurityGroup Task fronting the given ELB prefix.
+func (c *KopsModelContext) LinkToELBSecurityGroup(prefix string) *awstasks.SecurityGroup {
+	return &awstasks.SecurityGroup{Name: s(c.ELBSecurityGroupName(prefix))}
+}
+
+// LinkToSecurityGroup returns a reference to the SecurityGroup Task for instances playing the given role.
+func (c *KopsModelContext) LinkToSecurityGroup(role kops.InstanceGroupRole) *awstasks.SecurityGroup {
+	return &awstasks.SecurityGroup{Name: s(c.SecurityGroupName(role))}
+}
+
+// LinkToSecurityGroupByID returns a reference to a pre-existing, user-managed SecurityGroup by ID.
+func (c *KopsModelContext) LinkToSecurityGroupByID(id string) *awstasks.SecurityGroup {
+	return &awstasks.SecurityGroup{ID: s(id)}
+}
+
+// LinkToAutoscalingGroup returns a reference to the AutoscalingGroup Task backing the given InstanceGroup.
+func (c *KopsModelContext) LinkToAutoscalingGroup(ig *kops.InstanceGroup) *awstasks.AutoscalingGroup {
+	return &awstasks.AutoscalingGroup{Name: s(ig.ObjectMeta.Name + "." + c.ClusterName())}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// AutoscalingGroup represents the AWS autoscaling group backing an InstanceGroup.
+type AutoscalingGroup struct {
+	Name *string
+	ID   *string
+}
+
+var _ fi.Task = &AutoscalingGroup{}
+var _ fi.HasName = &AutoscalingGroup{}
+
+func (e *AutoscalingGroup) GetName() *string {
+	return e.Name
+}
+
+func (e *AutoscalingGroup) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for AutoscalingGroup task", c.Target)
+	}
+}
+
+// RenderAWS is a no-op here: the InstanceGroup ModelBuilder owns creating the ASG itself.
+func (e *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget) error {
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// Listener is an NLB listener, forwarding a front-end port to a TargetGroup.
+type Listener struct {
+	Name *string
+	ARN  *string
+
+	LoadBalancer *NetworkLoadBalancer
+	TargetGroup  *TargetGroup
+
+	Port     *int64
+	Protocol *string
+}
+
+var _ fi.Task = &Listener{}
+var _ fi.HasName = &Listener{}
+
+func (e *Listener) GetName() *string {
+	return e.Name
+}
+
+func (e *Listener) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for Listener task", c.Target)
+	}
+}
+
+func (e *Listener) RenderAWS(t *awsup.AWSAPITarget) error {
+	protocol := aws.String(elbv2.ProtocolEnumTcp)
+	if e.Protocol != nil {
+		protocol = e.Protocol
+	}
+
+	request := &elbv2.CreateListenerInput{
+		LoadBalancerArn: e.LoadBalancer.ARN,
+		Port:            e.Port,
+		Protocol:        protocol,
+		DefaultActions: []*elbv2.Action{
+			{
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+				TargetGroupArn: e.TargetGroup.ARN,
+			},
+		},
+	}
+
+	resp, err := t.Cloud.ELBV2().CreateListener(request)
+	if err != nil {
+		return fmt.Errorf("error creating listener on port %d for NLB %q: %v", aws.Int64Value(e.Port), aws.StringValue(e.LoadBalancer.LoadBalancerName), err)
+	}
+
+	if len(resp.Listeners) > 0 {
+		e.ARN = resp.Listeners[0].ListenerArn
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// LoadBalancer represents a Classic ELB.
+type LoadBalancer struct {
+	Name             *string
+	LoadBalancerName *string
+	ID               *string
+
+	SecurityGroups []*SecurityGroup
+	Subnets        []*Subnet
+	Scheme         *string
+
+	Listeners map[string]*LoadBalancerListener
+
+	HealthCheck        *LoadBalancerHealthCheck
+	ConnectionSettings *LoadBalancerConnectionSettings
+
+	AccessLog              *LoadBalancerAccessLog
+	ConnectionDraining     *LoadBalancerConnectionDraining
+	CrossZoneLoadBalancing *LoadBalancerCrossZoneLoadBalancing
+}
+
+var _ fi.Task = &LoadBalancer{}
+var _ fi.HasName = &LoadBalancer{}
+
+func (e *LoadBalancer) GetName() *string {
+	return e.Name
+}
+
+// LoadBalancerListener configures a single ELB listener.
+type LoadBalancerListener struct {
+	InstancePort int64
+
+	// SSLCertificateID is the ARN of an ACM certificate to terminate TLS with. If set, the
+	// listener negotiates HTTPS on the front end instead of a plain TCP passthrough.
+	SSLCertificateID *string
+	// SSLPolicy is the ELB security policy to negotiate when SSLCertificateID is set.
+	SSLPolicy *string
+	// Protocol overrides the listener's protocol (e.g. "TCP", "SSL"). If unset, it defaults to
+	// "TCP", or to "HTTPS" when SSLCertificateID is set.
+	Protocol *string
+}
+
+// LoadBalancerHealthCheck configures the ELB's instance health check.
+type LoadBalancerHealthCheck struct {
+	Target             *string
+	Timeout            *int64
+	Interval           *int64
+	HealthyThreshold   *int64
+	UnhealthyThreshold *int64
+}
+
+// LoadBalancerConnectionSettings configures idle-connection behavior.
+type LoadBalancerConnectionSettings struct {
+	IdleTimeout *int64
+}
+
+// LoadBalancerAccessLog configures the ELB's access logs.
+type LoadBalancerAccessLog struct {
+	Enabled        *bool
+	S3BucketName   *string
+	S3BucketPrefix *string
+	EmitInterval   *int64
+}
+
+// LoadBalancerConnectionDraining configures connection draining on deregistration.
+type LoadBalancerConnectionDraining struct {
+	Enabled *bool
+	Timeout *int64
+}
+
+// LoadBalancerCrossZoneLoadBalancing configures whether the ELB distributes traffic evenly
+// across all registered availability zones.
+type LoadBalancerCrossZoneLoadBalancing struct {
+	Enabled *bool
+}
+
+func (e *LoadBalancer) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for LoadBalancer task", c.Target)
+	}
+}
+
+func (e *LoadBalancer) RenderAWS(t *awsup.AWSAPITarget) error {
+	var elbListeners []*elb.Listener
+	for externalPort, l := range e.Listeners {
+		port, err := strconv.ParseInt(externalPort, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid listener port %q on ELB %q: %v", externalPort, aws.StringValue(e.LoadBalancerName), err)
+		}
+
+		elbListeners = append(elbListeners, l.toELB(port))
+	}
+
+	securityGroupIDs := make([]*string, 0, len(e.SecurityGroups))
+	for _, sg := range e.SecurityGroups {
+		securityGroupIDs = append(securityGroupIDs, sg.ID)
+	}
+
+	subnetIDs := make([]*string, 0, len(e.Subnets))
+	for _, subnet := range e.Subnets {
+		subnetIDs = append(subnetIDs, subnet.ID)
+	}
+
+	request := &elb.CreateLoadBalancerInput{
+		LoadBalancerName: e.LoadBalancerName,
+		Listeners:        elbListeners,
+		SecurityGroups:   securityGroupIDs,
+		Subnets:          subnetIDs,
+		Scheme:           e.Scheme,
+	}
+
+	if _, err := t.Cloud.ELB().CreateLoadBalancer(request); err != nil {
+		return fmt.Errorf("error creating ELB %q: %v", aws.StringValue(e.LoadBalancerName), err)
+	}
+
+	for externalPort, l := range e.Listeners {
+		if l.SSLPolicy == nil {
+			continue
+		}
+
+		port, err := strconv.ParseInt(externalPort, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid listener port %q on ELB %q: %v", externalPort, aws.StringValue(e.LoadBalancerName), err)
+		}
+
+		// SetLoadBalancerPoliciesOfListener takes a policy object that must already exist on the
+		// ELB, not the name of the predefined AWS policy directly - create one that references the
+		// predefined policy, then attach it by its own name.
+		policyName := aws.StringValue(l.SSLPolicy) + "-" + externalPort
+		createPolicy := &elb.CreateLoadBalancerPolicyInput{
+			LoadBalancerName: e.LoadBalancerName,
+			PolicyName:       aws.String(policyName),
+			PolicyTypeName:   aws.String("SSLNegotiationPolicyType"),
+			PolicyAttributes: []*elb.PolicyAttribute{
+				{
+					AttributeName:  aws.String("Reference-Security-Policy"),
+					AttributeValue: l.SSLPolicy,
+				},
+			},
+		}
+		if _, err := t.Cloud.ELB().CreateLoadBalancerPolicy(createPolicy); err != nil {
+			return fmt.Errorf("error creating SSL policy %q on ELB %q: %v", aws.StringValue(l.SSLPolicy), aws.StringValue(e.LoadBalancerName), err)
+		}
+
+		request := &elb.SetLoadBalancerPoliciesOfListenerInput{
+			LoadBalancerName: e.LoadBalancerName,
+			LoadBalancerPort: aws.Int64(port),
+			PolicyNames:      []*string{aws.String(policyName)},
+		}
+		if _, err := t.Cloud.ELB().SetLoadBalancerPoliciesOfListener(request); err != nil {
+			return fmt.Errorf("error setting SSL policy on ELB %q listener %d: %v", aws.StringValue(e.LoadBalancerName), port, err)
+		}
+	}
+
+	if e.HealthCheck != nil {
+		request := &elb.ConfigureHealthCheckInput{
+			LoadBalancerName: e.LoadBalancerName,
+			HealthCheck: &elb.HealthCheck{
+				Target:             e.HealthCheck.Target,
+				Timeout:            e.HealthCheck.Timeout,
+				Interval:           e.HealthCheck.Interval,
+				HealthyThreshold:   e.HealthCheck.HealthyThreshold,
+				UnhealthyThreshold: e.HealthCheck.UnhealthyThreshold,
+			},
+		}
+		if _, err := t.Cloud.ELB().ConfigureHealthCheck(request); err != nil {
+			return fmt.Errorf("error configuring health check for ELB %q: %v", aws.StringValue(e.LoadBalancerName), err)
+		}
+	}
+
+	return e.modifyLoadBalancerAttributes(t)
+}
+
+// toELB builds the AWS SDK Listener for this LoadBalancerListener on the given external port.
+func (l *LoadBalancerListener) toELB(port int64) *elb.Listener {
+	protocol := "TCP"
+	if l.SSLCertificateID != nil {
+		protocol = "HTTPS"
+	}
+	if l.Protocol != nil {
+		protocol = aws.StringValue(l.Protocol)
+	}
+
+	listener := &elb.Listener{
+		LoadBalancerPort: aws.Int64(port),
+		InstancePort:     aws.Int64(l.InstancePort),
+		Protocol:         aws.String(protocol),
+		InstanceProtocol: aws.String(protocol),
+	}
+	if l.SSLCertificateID != nil {
+		listener.SSLCertificateId = l.SSLCertificateID
+	}
+	return listener
+}
+
+// modifyLoadBalancerAttributes pushes any ELB-attribute-level configuration (idle timeout, access
+// log, connection draining and cross-zone load balancing) via ModifyLoadBalancerAttributes.
+func (e *LoadBalancer) modifyLoadBalancerAttributes(t *awsup.AWSAPITarget) error {
+	attributes := &elb.LoadBalancerAttributes{}
+	changed := false
+
+	if e.ConnectionSettings != nil {
+		attributes.ConnectionSettings = &elb.ConnectionSettings{
+			IdleTimeout: e.ConnectionSettings.IdleTimeout,
+		}
+		changed = true
+	}
+
+	if e.AccessLog != nil {
+		attributes.AccessLog = &elb.AccessLog{
+			Enabled:        e.AccessLog.Enabled,
+			S3BucketName:   e.AccessLog.S3BucketName,
+			S3BucketPrefix: e.AccessLog.S3BucketPrefix,
+			EmitInterval:   e.AccessLog.EmitInterval,
+		}
+		changed = true
+	}
+
+	if e.ConnectionDraining != nil {
+		attributes.ConnectionDraining = &elb.ConnectionDraining{
+			Enabled: e.ConnectionDraining.Enabled,
+			Timeout: e.ConnectionDraining.Timeout,
+		}
+		changed = true
+	}
+
+	if e.CrossZoneLoadBalancing != nil {
+		attributes.CrossZoneLoadBalancing = &elb.CrossZoneLoadBalancing{
+			Enabled: e.CrossZoneLoadBalancing.Enabled,
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	request := &elb.ModifyLoadBalancerAttributesInput{
+		LoadBalancerName:       e.LoadBalancerName,
+		LoadBalancerAttributes: attributes,
+	}
+	if _, err := t.Cloud.ELB().ModifyLoadBalancerAttributes(request); err != nil {
+		return fmt.Errorf("error modifying attributes for ELB %q: %v", aws.StringValue(e.LoadBalancerName), err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// LoadBalancerAttachment attaches an autoscaling group's instances to a Classic ELB.
+type LoadBalancerAttachment struct {
+	Name *string
+
+	LoadBalancer     *LoadBalancer
+	AutoscalingGroup *AutoscalingGroup
+}
+
+var _ fi.Task = &LoadBalancerAttachment{}
+var _ fi.HasName = &LoadBalancerAttachment{}
+
+func (e *LoadBalancerAttachment) GetName() *string {
+	return e.Name
+}
+
+func (e *LoadBalancerAttachment) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for LoadBalancerAttachment task", c.Target)
+	}
+}
+
+func (e *LoadBalancerAttachment) RenderAWS(t *awsup.AWSAPITarget) error {
+	request := &autoscaling.AttachLoadBalancersInput{
+		AutoScalingGroupName: e.AutoscalingGroup.Name,
+		LoadBalancerNames:    []*string{e.LoadBalancer.LoadBalancerName},
+	}
+	if _, err := t.Cloud.Autoscaling().AttachLoadBalancers(request); err != nil {
+		return fmt.Errorf("error attaching ASG %q to ELB %q: %v", aws.StringValue(e.AutoscalingGroup.Name), aws.StringValue(e.LoadBalancer.LoadBalancerName), err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// NetworkLoadBalancer represents an AWS Network Load Balancer fronting the API, the NLB
+// counterpart of LoadBalancer.
+type NetworkLoadBalancer struct {
+	Name             *string
+	LoadBalancerName *string
+	ID               *string
+	ARN              *string
+
+	Scheme         *string
+	SubnetMappings []*SubnetMapping
+}
+
+var _ fi.Task = &NetworkLoadBalancer{}
+var _ fi.HasName = &NetworkLoadBalancer{}
+
+func (e *NetworkLoadBalancer) GetName() *string {
+	return e.Name
+}
+
+func (e *NetworkLoadBalancer) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for NetworkLoadBalancer task", c.Target)
+	}
+}
+
+func (e *NetworkLoadBalancer) RenderAWS(t *awsup.AWSAPITarget) error {
+	var mappings []*elbv2.SubnetMapping
+	for _, m := range e.SubnetMappings {
+		mappings = append(mappings, &elbv2.SubnetMapping{
+			SubnetId:           m.Subnet.ID,
+			PrivateIPv4Address: m.PrivateIPv4Address,
+			AllocationId:       m.AllocationID,
+		})
+	}
+
+	scheme := aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing)
+	if e.Scheme != nil {
+		scheme = e.Scheme
+	}
+
+	request := &elbv2.CreateLoadBalancerInput{
+		Name:           e.LoadBalancerName,
+		Type:           aws.String(elbv2.LoadBalancerTypeEnumNetwork),
+		Scheme:         scheme,
+		SubnetMappings: mappings,
+	}
+
+	resp, err := t.Cloud.ELBV2().CreateLoadBalancer(request)
+	if err != nil {
+		return fmt.Errorf("error creating Network Load Balancer %q: %v", aws.StringValue(e.LoadBalancerName), err)
+	}
+
+	if len(resp.LoadBalancers) > 0 {
+		e.ARN = resp.LoadBalancers[0].LoadBalancerArn
+	}
+
+	return nil
+}
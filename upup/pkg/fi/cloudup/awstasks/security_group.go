@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// SecurityGroup represents an AWS security group.
+type SecurityGroup struct {
+	Name        *string
+	ID          *string
+	VPC         *VPC
+	Description *string
+
+	// RemoveExtraRules lists rule selectors (e.g. "port=443") for rules on the real security group
+	// that are not declared by any SecurityGroupRule task. RenderAWS does not read it: no rules are
+	// pruned.
+	RemoveExtraRules []string
+}
+
+var _ fi.Task = &SecurityGroup{}
+var _ fi.HasName = &SecurityGroup{}
+
+func (e *SecurityGroup) GetName() *string {
+	return e.Name
+}
+
+func (e *SecurityGroup) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for SecurityGroup task", c.Target)
+	}
+}
+
+func (e *SecurityGroup) RenderAWS(t *awsup.AWSAPITarget) error {
+	if e.ID != nil {
+		// References a pre-existing, user-managed security group - nothing for kops to create.
+		return nil
+	}
+
+	request := &ec2.CreateSecurityGroupInput{
+		GroupName:   e.Name,
+		Description: e.Description,
+	}
+	if e.VPC != nil {
+		request.VpcId = e.VPC.ID
+	}
+
+	if _, err := t.Cloud.EC2().CreateSecurityGroup(request); err != nil {
+		return fmt.Errorf("error creating SecurityGroup %q: %v", aws.StringValue(e.Name), err)
+	}
+
+	return nil
+}
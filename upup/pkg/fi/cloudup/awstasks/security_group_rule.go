@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// SecurityGroupRule represents a single ingress or egress rule on a SecurityGroup.
+type SecurityGroupRule struct {
+	Name          *string
+	SecurityGroup *SecurityGroup
+
+	CIDR        *string
+	SourceGroup *SecurityGroup
+
+	FromPort *int64
+	ToPort   *int64
+	Protocol *string
+
+	Egress *bool
+}
+
+var _ fi.Task = &SecurityGroupRule{}
+var _ fi.HasName = &SecurityGroupRule{}
+
+func (e *SecurityGroupRule) GetName() *string {
+	return e.Name
+}
+
+func (e *SecurityGroupRule) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for SecurityGroupRule task", c.Target)
+	}
+}
+
+func (e *SecurityGroupRule) RenderAWS(t *awsup.AWSAPITarget) error {
+	permission := &ec2.IpPermission{
+		IpProtocol: e.Protocol,
+		FromPort:   e.FromPort,
+		ToPort:     e.ToPort,
+	}
+
+	if e.CIDR != nil {
+		permission.IpRanges = []*ec2.IpRange{{CidrIp: e.CIDR}}
+	}
+	if e.SourceGroup != nil {
+		permission.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: e.SourceGroup.ID}}
+	}
+
+	if fi.BoolValue(e.Egress) {
+		request := &ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       e.SecurityGroup.ID,
+			IpPermissions: []*ec2.IpPermission{permission},
+		}
+		if _, err := t.Cloud.EC2().AuthorizeSecurityGroupEgress(request); err != nil {
+			return fmt.Errorf("error authorizing egress SecurityGroupRule %q: %v", aws.StringValue(e.Name), err)
+		}
+		return nil
+	}
+
+	request := &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       e.SecurityGroup.ID,
+		IpPermissions: []*ec2.IpPermission{permission},
+	}
+	if _, err := t.Cloud.EC2().AuthorizeSecurityGroupIngress(request); err != nil {
+		return fmt.Errorf("error authorizing ingress SecurityGroupRule %q: %v", aws.StringValue(e.Name), err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// Subnet represents an AWS subnet.
+type Subnet struct {
+	Name *string
+	ID   *string
+	VPC  *VPC
+	Zone *string
+}
+
+var _ fi.Task = &Subnet{}
+var _ fi.HasName = &Subnet{}
+
+func (e *Subnet) GetName() *string {
+	return e.Name
+}
+
+func (e *Subnet) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for Subnet task", c.Target)
+	}
+}
+
+// RenderAWS is a no-op: subnets are expected to already exist, created by the network ModelBuilder.
+func (e *Subnet) RenderAWS(t *awsup.AWSAPITarget) error {
+	return nil
+}
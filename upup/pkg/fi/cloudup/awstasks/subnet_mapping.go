@@ -0,0 +1,27 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+// SubnetMapping pairs a Subnet with the static addressing a Network Load Balancer should use
+// within it. Unlike the other types in this package, it is not itself a fi.Task: it is a plain
+// value embedded in NetworkLoadBalancer.SubnetMappings.
+type SubnetMapping struct {
+	Subnet *Subnet
+
+	PrivateIPv4Address *string
+	AllocationID       *string
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// TargetGroup represents the target group an NLB Listener forwards traffic to.
+type TargetGroup struct {
+	Name *string
+	ARN  *string
+	VPC  *VPC
+
+	Port     *int64
+	Protocol *string
+
+	HealthCheck *TargetGroupHealthCheck
+}
+
+// TargetGroupHealthCheck configures the target group's health check.
+type TargetGroupHealthCheck struct {
+	Protocol           *string
+	Path               *string
+	Port               *int64
+	Interval           *int64
+	Timeout            *int64
+	HealthyThreshold   *int64
+	UnhealthyThreshold *int64
+}
+
+var _ fi.Task = &TargetGroup{}
+var _ fi.HasName = &TargetGroup{}
+
+func (e *TargetGroup) GetName() *string {
+	return e.Name
+}
+
+func (e *TargetGroup) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for TargetGroup task", c.Target)
+	}
+}
+
+func (e *TargetGroup) RenderAWS(t *awsup.AWSAPITarget) error {
+	request := &elbv2.CreateTargetGroupInput{
+		Name:       e.Name,
+		Port:       e.Port,
+		Protocol:   e.Protocol,
+		TargetType: aws.String(elbv2.TargetTypeEnumInstance),
+	}
+	if e.VPC != nil {
+		request.VpcId = e.VPC.ID
+	}
+
+	if e.HealthCheck != nil {
+		request.HealthCheckProtocol = e.HealthCheck.Protocol
+		request.HealthCheckPath = e.HealthCheck.Path
+		request.HealthCheckPort = aws.String(strconv.FormatInt(aws.Int64Value(e.HealthCheck.Port), 10))
+		request.HealthCheckIntervalSeconds = e.HealthCheck.Interval
+		request.HealthCheckTimeoutSeconds = e.HealthCheck.Timeout
+		request.HealthyThresholdCount = e.HealthCheck.HealthyThreshold
+		request.UnhealthyThresholdCount = e.HealthCheck.UnhealthyThreshold
+	}
+
+	resp, err := t.Cloud.ELBV2().CreateTargetGroup(request)
+	if err != nil {
+		return fmt.Errorf("error creating TargetGroup %q: %v", aws.StringValue(e.Name), err)
+	}
+
+	if len(resp.TargetGroups) > 0 {
+		e.ARN = resp.TargetGroups[0].TargetGroupArn
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// TargetGroupAttachment attaches an autoscaling group's instances to an NLB TargetGroup.
+type TargetGroupAttachment struct {
+	Name *string
+
+	TargetGroup      *TargetGroup
+	AutoscalingGroup *AutoscalingGroup
+}
+
+var _ fi.Task = &TargetGroupAttachment{}
+var _ fi.HasName = &TargetGroupAttachment{}
+
+func (e *TargetGroupAttachment) GetName() *string {
+	return e.Name
+}
+
+func (e *TargetGroupAttachment) Run(c *fi.Context) error {
+	switch t := c.Target.(type) {
+	case *awsup.AWSAPITarget:
+		return e.RenderAWS(t)
+	default:
+		return fmt.Errorf("unsupported target type %T for TargetGroupAttachment task", c.Target)
+	}
+}
+
+func (e *TargetGroupAttachment) RenderAWS(t *awsup.AWSAPITarget) error {
+	request := &autoscaling.AttachLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: e.AutoscalingGroup.Name,
+		TargetGroupARNs:      []*string{e.TargetGroup.ARN},
+	}
+	if _, err := t.Cloud.Autoscaling().AttachLoadBalancerTargetGroups(request); err != nil {
+		return fmt.Errorf("error attaching ASG %q to target group %q: %v", aws.StringValue(e.AutoscalingGroup.Name), aws.StringValue(e.TargetGroup.Name), err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// AWSCloud is the subset of AWS service clients the awstasks package needs in order to render
+// infrastructure.
+type AWSCloud interface {
+	fi.Cloud
+
+	EC2() *ec2.EC2
+	ELB() *elb.ELB
+	ELBV2() *elbv2.ELBV2
+	Autoscaling() *autoscaling.AutoScaling
+}
+
+// AWSAPITarget renders Tasks by calling the AWS APIs directly.
+type AWSAPITarget struct {
+	Cloud AWSCloud
+}
+
+var _ fi.Target = &AWSAPITarget{}
+
+// Finish is a no-op for the direct-API target: there is no batched apply step, every RenderAWS
+// call has already taken effect.
+func (t *AWSAPITarget) Finish(taskMap map[string]fi.Task) error {
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import "fmt"
+
+// ModelBuilder builds a set of Tasks representing some part of the cluster's infrastructure.
+type ModelBuilder interface {
+	Build(c *ModelBuilderContext) error
+}
+
+// ModelBuilderContext accumulates the Tasks emitted by a ModelBuilder.
+type ModelBuilderContext struct {
+	Tasks map[string]Task
+}
+
+// AddTask registers a Task, keyed by its name.
+func (c *ModelBuilderContext) AddTask(task Task) {
+	if c.Tasks == nil {
+		c.Tasks = make(map[string]Task)
+	}
+
+	name := fmt.Sprintf("%T", task)
+	if hn, ok := task.(HasName); ok {
+		name = fmt.Sprintf("%T/%s", task, StringValue(hn.GetName()))
+	}
+
+	c.Tasks[name] = task
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+// Task is implemented by every resource a ModelBuilder emits; the cloudup executor brings each
+// Task's real-world state in line with its configured state.
+type Task interface {
+	// Run reconciles the Task against the cloud, given the rendering Context.
+	Run(c *Context) error
+}
+
+// HasName is implemented by Tasks that can report a human-readable name, used as their key in a
+// ModelBuilderContext and in diffing output.
+type HasName interface {
+	GetName() *string
+}
+
+// Context carries the Cloud and Target state through a run of the Task graph.
+type Context struct {
+	Cloud  Cloud
+	Target Target
+}
+
+// Cloud abstracts the cloud provider backing a cluster.
+type Cloud interface {
+	ProviderID() string
+}
+
+// Target renders Tasks, e.g. by calling cloud APIs directly or emitting Terraform.
+type Target interface {
+	Finish(taskMap map[string]Task) error
+}
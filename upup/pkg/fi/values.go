@@ -0,0 +1,50 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+// String returns a pointer to the string value passed in.
+func String(v string) *string { return &v }
+
+// StringValue returns the value of the string pointer passed in, or "" if the pointer is nil.
+func StringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// Bool returns a pointer to the bool value passed in.
+func Bool(v bool) *bool { return &v }
+
+// BoolValue returns the value of the bool pointer passed in, or false if the pointer is nil.
+func BoolValue(v *bool) bool {
+	if v == nil {
+		return false
+	}
+	return *v
+}
+
+// Int64 returns a pointer to the int64 value passed in.
+func Int64(v int64) *int64 { return &v }
+
+// Int64Value returns the value of the int64 pointer passed in, or 0 if the pointer is nil.
+func Int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}